@@ -9,27 +9,40 @@ import (
 	"go.senan.xyz/table/table"
 )
 
-// $ stream | table [ <separator> [ <flush interval> [ <prefix> [ <suffix> ] ] ] ]
+// $ stream | table [ -format <name> ] [ <separator> [ <flush interval> [ <prefix> [ <suffix> ] ] ] ]
 
 func main() {
 	var prefix, separator, suffix = "", " ", ""
 	var flushInterval int
+	var format string
 
-	if n := 1; len(os.Args) > n {
-		separator = os.Args[n]
+	args := os.Args[1:]
+	for i := 0; i < len(args); i++ {
+		if args[i] == "-format" && i+1 < len(args) {
+			format = args[i+1]
+			args = append(args[:i], args[i+2:]...)
+			break
+		}
+	}
+
+	if n := 0; len(args) > n {
+		separator = args[n]
 	}
-	if n := 2; len(os.Args) > n {
-		flushInterval, _ = strconv.Atoi(os.Args[n])
+	if n := 1; len(args) > n {
+		flushInterval, _ = strconv.Atoi(args[n])
 	}
-	if n := 3; len(os.Args) > n {
-		prefix = os.Args[n]
+	if n := 2; len(args) > n {
+		prefix = args[n]
 	}
-	if n := 4; len(os.Args) > n {
-		suffix = os.Args[n]
+	if n := 3; len(args) > n {
+		suffix = args[n]
 	}
 
 	w := table.New(os.Stdout)
 	w.SetFormat(prefix, separator, suffix)
+	if r := renderer(format); r != nil {
+		w.SetRenderer(r)
+	}
 
 	// no flush interval, just copy
 	if flushInterval == 0 {
@@ -69,3 +82,22 @@ L:
 		panic(err)
 	}
 }
+
+// renderer picks a table.Renderer by name for the -format flag, returning nil
+// for "" or an unrecognised name so the default space-padded output is kept.
+func renderer(format string) table.Renderer {
+	switch format {
+	case "markdown", "md":
+		return table.NewMarkdownRenderer()
+	case "box":
+		return table.BoxRenderer{}
+	case "csv":
+		return &table.CSVRenderer{}
+	case "tsv":
+		return table.NewTSVRenderer()
+	case "json":
+		return &table.JSONRenderer{}
+	default:
+		return nil
+	}
+}
@@ -3,12 +3,18 @@ package table
 
 import (
 	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"regexp"
+	"strconv"
 	"strings"
 
 	"github.com/rivo/uniseg"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
 )
 
 type Writer struct {
@@ -16,10 +22,68 @@ type Writer struct {
 	buf            []byte
 	pre, sep, suff string
 	widths         []int
+	aligns         []Align
+	intWidths      []int // per-column max integer-part width, for AlignDecimal
+	fracWidths     []int // per-column max fractional-part width, for AlignDecimal
+	printer        *message.Printer
+	indent         string
+	mode           writeMode
+	streaming      bool
+	policy         OverflowPolicy
+	maxWidths      []int
+	ellipsis       string
+	renderer       Renderer
 	rows           [][]string
 	err            error // first error (e.g., ColumnCountError) recorded during Write
+	lineNum        int
 }
 
+// Renderer controls how Flush turns buffered rows into output lines. Header
+// and Footer frame the table (e.g. a box-drawing border) and are skipped when
+// they return "". Separator is called once, immediately after the first
+// buffered row, so renderers can underline a header row; it's skipped the
+// same way.
+type Renderer interface {
+	Header(widths []int) string
+	Row(cols []string, widths []int) string
+	Separator(widths []int) string
+	Footer(widths []int) string
+}
+
+// OverflowPolicy controls how a streaming Writer handles a cell wider than
+// its column's width.
+type OverflowPolicy int
+
+const (
+	Grow     OverflowPolicy = iota // let the column grow to fit (default, non-streaming behavior)
+	Truncate                       // clip the cell to width, appending an ellipsis
+	Wrap                           // reflow the cell into extra lines within the column's width
+)
+
+// writeMode tracks whether a Writer has been used as a flat Write-r or as a
+// WriteTree-r, so the two can't be mixed on the same Writer.
+type writeMode int
+
+const (
+	modeNone writeMode = iota
+	modeFlat
+	modeTree
+)
+
+// ErrMixedUse is returned by WriteTree, or recorded by Write and returned by
+// the next Flush, when the other has already been used on this Writer.
+var ErrMixedUse = errors.New("table: Write and WriteTree cannot be used on the same Writer")
+
+// Align selects how a column's cells are padded within its width.
+type Align int
+
+const (
+	AlignLeft Align = iota
+	AlignRight
+	AlignCenter
+	AlignDecimal // align numeric cells on the locale's decimal separator
+)
+
 // New constructs a new Writer that will emit formatted rows to out on Flush/Close.
 func New(out io.Writer) *Writer {
 	return &Writer{
@@ -35,6 +99,175 @@ func (w *Writer) SetFormat(pre, sep, suff string) {
 	w.suff = suff
 }
 
+// SetColumnAlign sets the alignment of col, which defaults to AlignLeft.
+// AlignDecimal additionally requires the cell to parse as a number; cells that
+// don't parse are right-aligned against the numbers' integer parts, or against
+// the column's right edge if they are too wide for that.
+func (w *Writer) SetColumnAlign(col int, align Align) {
+	if col >= len(w.aligns) {
+		grown := make([]Align, col+1)
+		copy(grown, w.aligns)
+		w.aligns = grown
+	}
+	w.aligns[col] = align
+}
+
+// SetLocale enables locale-aware formatting of AlignDecimal columns, using tag's
+// thousands and decimal separators (e.g. "1,234.5" vs "1.234,5").
+func (w *Writer) SetLocale(tag language.Tag) {
+	w.printer = message.NewPrinter(tag)
+}
+
+// SetStreaming puts w into streaming mode: Flush emits immediately using the
+// widest widths seen across all flushes so far and never shrinks a column
+// afterwards, instead of recomputing widths from scratch each time. minColWidths
+// seeds the initial per-column widths (nil for none); policy controls how cells
+// wider than a column's width (or its SetMaxWidths cap) are rendered.
+func (w *Writer) SetStreaming(minColWidths []int, policy OverflowPolicy) {
+	w.streaming = true
+	w.policy = policy
+	if minColWidths != nil {
+		w.widths = append([]int(nil), minColWidths...)
+		w.intWidths = make([]int, len(minColWidths))
+		w.fracWidths = make([]int, len(minColWidths))
+	}
+}
+
+// SetMaxWidths sets a hard per-column width cap: once a column reaches its
+// cap it stops growing, and the configured OverflowPolicy takes over for
+// cells that don't fit.
+func (w *Writer) SetMaxWidths(maxWidths []int) {
+	w.maxWidths = maxWidths
+}
+
+// SetEllipsis sets the marker OverflowPolicy Truncate appends to clipped
+// cells; defaults to "…".
+func (w *Writer) SetEllipsis(ellipsis string) {
+	w.ellipsis = ellipsis
+}
+
+// SetRenderer selects r to render buffered rows on the next Flush, replacing
+// the Writer's own space-padded, alignment-aware output. If r reads back
+// Writer config (e.g. MarkdownRenderer's column alignment), it is bound to w.
+func (w *Writer) SetRenderer(r Renderer) {
+	w.renderer = r
+	if wa, ok := r.(writerAware); ok {
+		wa.bindWriter(w)
+	}
+}
+
+// writerAware is implemented by renderers that need to read back Writer
+// config rather than have it duplicated on the renderer itself. SetRenderer
+// binds the Writer automatically.
+type writerAware interface {
+	bindWriter(w *Writer)
+}
+
+// SetIndent sets the unit WriteTree uses to build its tree-drawing prefixes.
+// Defaults to three spaces; its width should be at least 2 for the connector
+// glyphs to render sensibly.
+func (w *Writer) SetIndent(indent string) {
+	w.indent = indent
+}
+
+// Node is a row in a tree passed to Writer.WriteTree. Build one with NewNode
+// and grow it with AddChild.
+type Node struct {
+	cols     []string
+	children []*Node
+}
+
+// NewNode constructs a root Node with the given column values.
+func NewNode(cols ...string) *Node {
+	return &Node{cols: cols}
+}
+
+// AddChild appends a child row under n and returns it, so subtrees can be
+// built by chaining further AddChild calls.
+func (n *Node) AddChild(cols ...string) *Node {
+	child := &Node{cols: cols}
+	n.children = append(n.children, child)
+	return child
+}
+
+// WriteTree buffers root and its descendants as rows, prefixing each row's
+// first column with tree-drawing runes derived from its depth and whether it
+// is the last child of its parent. Call Flush to emit them. WriteTree and
+// Write cannot be used on the same Writer.
+func (w *Writer) WriteTree(root *Node) error {
+	if w.mode == modeFlat {
+		if w.err == nil {
+			w.err = ErrMixedUse
+		}
+		return ErrMixedUse
+	}
+	w.mode = modeTree
+	w.writeNode(root, nil, false, true)
+	return w.err
+}
+
+func (w *Writer) writeNode(n *Node, ancestorsLast []bool, isLast, isRoot bool) {
+	cols := append([]string(nil), n.cols...)
+	if !isRoot && len(cols) > 0 {
+		cols[0] = w.treePrefix(ancestorsLast, isLast) + cols[0]
+	}
+	if err := w.addRow(cols, 0); err != nil && w.err == nil {
+		w.err = err
+	}
+	childAncestors := ancestorsLast
+	if !isRoot {
+		childAncestors = append(append([]bool{}, ancestorsLast...), isLast)
+	}
+	for i, child := range n.children {
+		w.writeNode(child, childAncestors, i == len(n.children)-1, false)
+	}
+}
+
+// treePrefix renders one ancestorsLast/isLast pair per depth level into the
+// tree-drawing glyphs, e.g. "│  ├─ " for a non-last row two levels deep whose
+// parent was not a last child.
+func (w *Writer) treePrefix(ancestorsLast []bool, isLast bool) string {
+	g := w.treeGlyphs()
+	var sb strings.Builder
+	for _, last := range ancestorsLast {
+		if last {
+			sb.WriteString(g.blank)
+		} else {
+			sb.WriteString(g.bar)
+		}
+	}
+	if isLast {
+		sb.WriteString(g.corner)
+	} else {
+		sb.WriteString(g.tee)
+	}
+	return sb.String()
+}
+
+type treeGlyphs struct {
+	blank, bar, tee, corner string
+}
+
+func (w *Writer) treeGlyphs() treeGlyphs {
+	unit := w.indent
+	if unit == "" {
+		unit = "   "
+	}
+	width := strWidth(unit)
+	pad := func(n int) string {
+		if n < 0 {
+			n = 0
+		}
+		return strings.Repeat(" ", n)
+	}
+	return treeGlyphs{
+		blank:  unit,
+		bar:    "│" + pad(width-1),
+		tee:    "├─" + pad(width-2),
+		corner: "└─" + pad(width-2),
+	}
+}
+
 // Write ingests bytes, splitting on '\n' (handles optional trailing '\r').
 // Parsed lines are buffered; call Flush or Close to write formatted output to out.
 // Column-count errors are recorded and surfaced on Flush/Close; subsequent lines are still processed.
@@ -50,6 +283,7 @@ func (w *Writer) Write(p []byte) (int, error) {
 		if len(line) > 0 && line[len(line)-1] == '\r' {
 			line = line[:len(line)-1] // handle \r\n
 		}
+		w.lineNum++
 		if err := w.addLine(line); err != nil && w.err == nil {
 			w.err = err
 		}
@@ -59,8 +293,10 @@ func (w *Writer) Write(p []byte) (int, error) {
 	return len(p), nil
 }
 
-// Flush formats all buffered rows and writes them to out, then resets rows/widths.
-// Returns the first error encountered during Write/addLine or any write error.
+// Flush renders all buffered rows via the configured Renderer (the Writer
+// itself, unless SetRenderer was called) and writes them to out, then resets
+// rows/widths. Returns the first error encountered during Write/addLine or
+// any write error.
 func (w *Writer) Flush() error {
 	if len(w.rows) == 0 {
 		// nothing to emit; still report any earlier error
@@ -69,13 +305,35 @@ func (w *Writer) Flush() error {
 		return err
 	}
 
-	var sep = " "
-	if w.sep != "" {
-		sep = " " + w.sep + " "
+	renderer := w.renderer
+	if renderer == nil {
+		renderer = writerRenderer{w}
 	}
+	_, grid := renderer.(gridRenderer)
 
-	for _, row := range w.rows {
-		line := formatRow(row, w.widths, w.pre, sep, w.suff)
+	var lines []string
+	if h := renderer.Header(w.widths); h != "" {
+		lines = append(lines, h)
+	}
+	for i, row := range w.rows {
+		if grid {
+			for _, sub := range w.gridSubRows(row) {
+				lines = append(lines, renderer.Row(sub, w.widths))
+			}
+		} else {
+			lines = append(lines, renderer.Row(row, w.widths))
+		}
+		if i == 0 {
+			if s := renderer.Separator(w.widths); s != "" {
+				lines = append(lines, s)
+			}
+		}
+	}
+	if f := renderer.Footer(w.widths); f != "" {
+		lines = append(lines, f)
+	}
+
+	for _, line := range lines {
 		if _, err := io.WriteString(w.out, line+"\n"); err != nil {
 			// preserve original write-time error if it existed; otherwise, set this write error
 			if w.err == nil {
@@ -90,64 +348,526 @@ func (w *Writer) Flush() error {
 	return err
 }
 
+// rowSep is the column separator writerRenderer puts between cells, derived
+// from SetFormat's sep.
+func (w *Writer) rowSep() string {
+	sep := " "
+	if w.sep != "" {
+		sep = " " + w.sep + " "
+	}
+	return sep
+}
+
+// gridRenderer is implemented by renderers that present a padded monospace
+// grid, so Flush pre-applies the Writer's alignment, truncation, and
+// wrapping (via gridSubRows) before handing cells to Row. Renderers that
+// emit a row's buffered values (MarkdownRenderer, CSVRenderer, JSONRenderer)
+// don't implement it; their cells are not padded, truncated or wrapped.
+// AlignDecimal cells still reach them number-formatted, and MarkdownRenderer
+// reads column alignment for its separator row.
+type gridRenderer interface {
+	grid()
+}
+
+// writerRenderer is installed on a Writer that hasn't called SetRenderer: it
+// reproduces the Writer's own space-padded, alignment-aware formatting.
+// Header, Separator, and Footer are no-ops, since that output has no framing.
+type writerRenderer struct{ w *Writer }
+
+func (writerRenderer) grid() {}
+
+func (writerRenderer) Header(widths []int) string    { return "" }
+func (writerRenderer) Separator(widths []int) string { return "" }
+func (writerRenderer) Footer(widths []int) string    { return "" }
+
+func (r writerRenderer) Row(cols []string, widths []int) string {
+	var sb strings.Builder
+	sb.WriteString(r.w.pre)
+	sb.WriteString(strings.Join(cols, r.w.rowSep()))
+	sb.WriteString(r.w.suff)
+	return sb.String()
+}
+
 func (w *Writer) reset() {
 	w.rows = nil
-	w.widths = nil
+	if !w.streaming {
+		w.widths = nil
+		w.intWidths = nil
+		w.fracWidths = nil
+	}
 	w.err = nil
+	w.lineNum = 0
 }
 
 // addLine parses, trims, validates column count, updates widths, and buffers the row.
 func (w *Writer) addLine(line string) error {
+	if w.mode == modeTree {
+		return ErrMixedUse
+	}
+	w.mode = modeFlat
+
 	cols := strings.Split(line, "\t")
 	for i := range cols {
 		cols[i] = strings.TrimSpace(cols[i])
 	}
+	return w.addRow(cols, w.lineNum)
+}
 
+// addRow validates column count, updates widths, and buffers cols as a row.
+// Used by both addLine and WriteTree, the latter having already baked its
+// tree-drawing prefix into cols[0] and having no line number to report.
+func (w *Writer) addRow(cols []string, lineNum int) error {
 	if w.widths == nil {
 		// initialize widths to number of columns in the first row
 		w.widths = make([]int, len(cols))
+		w.intWidths = make([]int, len(cols))
+		w.fracWidths = make([]int, len(cols))
 	}
 
 	if len(cols) != len(w.widths) {
-		return &ColumnCountError{Want: len(w.widths), Got: len(cols)}
+		return &ColumnCountError{Want: len(w.widths), Got: len(cols), Line: lineNum}
 	}
 
 	for i, c := range cols {
+		if w.alignOf(i) == AlignDecimal {
+			if n, err := strconv.ParseFloat(c, 64); err == nil {
+				c = w.formatDecimal(n)
+				cols[i] = c
+				intPart, fracPart, _ := strings.Cut(c, w.decimalSep())
+				if iw := strWidth(intPart); iw > w.intWidths[i] {
+					w.intWidths[i] = iw
+				}
+				if fw := strWidth(fracPart); fw > w.fracWidths[i] {
+					w.fracWidths[i] = fw
+				}
+			}
+			if dw := w.decimalWidth(i); dw > w.widths[i] {
+				if i < len(w.maxWidths) && w.maxWidths[i] > 0 && dw > w.maxWidths[i] {
+					dw = w.maxWidths[i]
+				}
+				if dw > w.widths[i] {
+					w.widths[i] = dw
+				}
+			}
+		}
 		if cw := strWidth(c); cw > w.widths[i] {
-			w.widths[i] = cw
+			if i < len(w.maxWidths) && w.maxWidths[i] > 0 && cw > w.maxWidths[i] {
+				cw = w.maxWidths[i]
+			}
+			if cw > w.widths[i] {
+				w.widths[i] = cw
+			}
 		}
 	}
 	w.rows = append(w.rows, cols)
 	return nil
 }
 
-func formatRow(row []string, widths []int, pre, sep, suff string) string {
+// alignOf returns the alignment of col, defaulting to AlignLeft.
+func (w *Writer) alignOf(col int) Align {
+	if col < len(w.aligns) {
+		return w.aligns[col]
+	}
+	return AlignLeft
+}
+
+// formatDecimal renders n with w.printer's locale, if set: the integer part
+// gets locale-aware digit grouping, and the two parts are joined on the
+// locale's decimal separator. Falls back to plain formatting otherwise.
+func (w *Writer) formatDecimal(n float64) string {
+	s := strconv.FormatFloat(n, 'f', -1, 64)
+	if w.printer == nil {
+		return s
+	}
+	intPart, fracPart, hasFrac := strings.Cut(s, ".")
+	intVal, err := strconv.ParseInt(intPart, 10, 64)
+	if err != nil {
+		return w.printer.Sprintf("%v", n)
+	}
+	out := w.printer.Sprintf("%d", intVal)
+	if intVal == 0 && strings.HasPrefix(intPart, "-") {
+		out = "-" + out
+	}
+	if hasFrac {
+		out += w.decimalSep() + fracPart
+	}
+	return out
+}
+
+// decimalSep reports the decimal separator that w.printer formats floats with.
+func (w *Writer) decimalSep() string {
+	if w.printer == nil {
+		return "."
+	}
+	for _, r := range w.printer.Sprintf("%.1f", 1.0) {
+		if r < '0' || r > '9' {
+			return string(r)
+		}
+	}
+	return "."
+}
+
+func (w *Writer) formatRow(row []string, sep string) string {
 	var sb strings.Builder
-	sb.WriteString(pre)
+	sb.WriteString(w.pre)
 
 	for i, col := range row {
 		if i != 0 {
 			sb.WriteString(sep)
 		}
-		sb.WriteString(col)
-		if i < len(widths) {
-			pad := widths[i] - strWidth(col)
-			if pad > 0 {
-				sb.WriteString(strings.Repeat(" ", pad))
-			}
+		width := 0
+		if i < len(w.widths) {
+			width = w.widths[i]
 		}
+		sb.WriteString(w.formatCell(i, col, width))
 	}
 
-	sb.WriteString(suff)
+	sb.WriteString(w.suff)
 	return sb.String()
 }
 
+// formatCell pads col to width according to its column's alignment.
+func (w *Writer) formatCell(col int, cell string, width int) string {
+	if w.streaming && w.policy == Truncate {
+		cell = w.truncateCell(cell, width)
+	}
+	switch w.alignOf(col) {
+	case AlignRight:
+		return padLeft(cell, width)
+	case AlignCenter:
+		return padCenter(cell, width)
+	case AlignDecimal:
+		return w.padDecimal(col, cell, width)
+	default:
+		return padRight(cell, width)
+	}
+}
+
+// decimalWidth reports the render width of col's AlignDecimal cells: the
+// integer part plus, if any row in col has a fractional part, the decimal
+// separator and fractional part too. Columns that never see a fraction
+// render at their plain integer width.
+func (w *Writer) decimalWidth(col int) int {
+	var intWidth, fracWidth int
+	if col < len(w.intWidths) {
+		intWidth = w.intWidths[col]
+	}
+	if col < len(w.fracWidths) {
+		fracWidth = w.fracWidths[col]
+	}
+	if fracWidth == 0 {
+		return intWidth
+	}
+	return intWidth + strWidth(w.decimalSep()) + fracWidth
+}
+
+// padDecimal pads cell so its integer and fractional parts line up across rows.
+// Cells that didn't parse as numbers (so were never locale-formatted), and
+// whole-number cells in a column that also holds fractional ones, are padded
+// into intWidth with a blank tail the width of the separator and fracWidth.
+// The aligned cell is then right-aligned within width; a cell too wide to
+// align that way is right-aligned within width as is, so every cell in the
+// column renders at the same total width.
+func (w *Writer) padDecimal(col int, cell string, width int) string {
+	sep := w.decimalSep()
+	var intWidth, fracWidth int
+	if col < len(w.intWidths) {
+		intWidth = w.intWidths[col]
+	}
+	if col < len(w.fracWidths) {
+		fracWidth = w.fracWidths[col]
+	}
+	intPart, fracPart, ok := strings.Cut(cell, sep)
+	var s string
+	switch {
+	case !ok && fracWidth == 0:
+		s = padLeft(intPart, intWidth)
+	case !ok:
+		s = padLeft(intPart, intWidth) + strings.Repeat(" ", strWidth(sep)+fracWidth)
+	default:
+		s = padLeft(intPart, intWidth) + sep + padRight(fracPart, fracWidth)
+	}
+	if strWidth(s) > width {
+		return padLeft(cell, width)
+	}
+	return padLeft(s, width)
+}
+
+func padRight(s string, width int) string {
+	if pad := width - strWidth(s); pad > 0 {
+		return s + strings.Repeat(" ", pad)
+	}
+	return s
+}
+
+func padLeft(s string, width int) string {
+	if pad := width - strWidth(s); pad > 0 {
+		return strings.Repeat(" ", pad) + s
+	}
+	return s
+}
+
+func padCenter(s string, width int) string {
+	pad := width - strWidth(s)
+	if pad <= 0 {
+		return s
+	}
+	left := pad / 2
+	return strings.Repeat(" ", left) + s + strings.Repeat(" ", pad-left)
+}
+
+// truncateCell clips s to width, replacing the tail with w.ellipsis
+// (defaulting to "…") if it doesn't fit.
+func (w *Writer) truncateCell(s string, width int) string {
+	if strWidth(s) <= width {
+		return s
+	}
+	ellipsis := w.ellipsis
+	if ellipsis == "" {
+		ellipsis = "…"
+	}
+	budget := width - strWidth(ellipsis)
+	if budget <= 0 {
+		return ellipsis
+	}
+	var sb strings.Builder
+	used := 0
+	g := uniseg.NewGraphemes(s)
+	for used < budget && g.Next() {
+		if cw := uniseg.StringWidth(g.Str()); used+cw <= budget {
+			sb.WriteString(g.Str())
+			used += cw
+		} else {
+			break
+		}
+	}
+	return sb.String() + ellipsis
+}
+
+// wrapCell splits s into lines that each fit within width, breaking on
+// grapheme-cluster boundaries. Returns []string{s} if it already fits.
+func wrapCell(s string, width int) []string {
+	if width <= 0 || strWidth(s) <= width {
+		return []string{s}
+	}
+	var lines []string
+	var sb strings.Builder
+	used := 0
+	g := uniseg.NewGraphemes(s)
+	for g.Next() {
+		cw := uniseg.StringWidth(g.Str())
+		if used+cw > width && sb.Len() > 0 {
+			lines = append(lines, sb.String())
+			sb.Reset()
+			used = 0
+		}
+		sb.WriteString(g.Str())
+		used += cw
+	}
+	lines = append(lines, sb.String())
+	return lines
+}
+
+// gridSubRows formats row for a gridRenderer, applying alignment and
+// truncation and, under a Wrap overflow policy, splitting it into however
+// many sub-rows its wrapped cells need. Each sub-row holds one
+// already-formatted (padded/aligned) string per column, ready for
+// Renderer.Row. Most rows produce a single sub-row.
+func (w *Writer) gridSubRows(row []string) [][]string {
+	if w.streaming && w.policy == Wrap {
+		return w.wrapGridRow(row)
+	}
+	cols := make([]string, len(row))
+	for i, col := range row {
+		width := 0
+		if i < len(w.widths) {
+			width = w.widths[i]
+		}
+		cols[i] = w.formatCell(i, col, width)
+	}
+	return [][]string{cols}
+}
+
+// wrapGridRow wraps any cell wider than its column's width, padding shorter
+// columns' extra lines with blanks, and returns one sub-row per output line.
+func (w *Writer) wrapGridRow(row []string) [][]string {
+	cellLines := make([][]string, len(row))
+	numLines := 1
+	for i, col := range row {
+		width := 0
+		if i < len(w.widths) {
+			width = w.widths[i]
+		}
+		wrapped := wrapCell(col, width)
+		cellLines[i] = make([]string, len(wrapped))
+		for j, part := range wrapped {
+			cellLines[i][j] = w.formatCell(i, part, width)
+		}
+		if len(wrapped) > numLines {
+			numLines = len(wrapped)
+		}
+	}
+
+	subRows := make([][]string, numLines)
+	for ln := 0; ln < numLines; ln++ {
+		sub := make([]string, len(row))
+		for i := range row {
+			if ln < len(cellLines[i]) {
+				sub[i] = cellLines[i][ln]
+			} else {
+				width := 0
+				if i < len(w.widths) {
+					width = w.widths[i]
+				}
+				sub[i] = strings.Repeat(" ", width)
+			}
+		}
+		subRows[ln] = sub
+	}
+	return subRows
+}
+
+// MarkdownRenderer renders a GitHub-flavored Markdown table: the first row
+// becomes the header, followed by a "|---|:--:|"-style separator driven by
+// the Writer's SetColumnAlign, with body rows as plain "| a | b |" lines.
+type MarkdownRenderer struct {
+	w *Writer
+}
+
+// NewMarkdownRenderer constructs a MarkdownRenderer. Its separator row
+// reflects alignment set via the Writer's SetColumnAlign once it's passed to
+// SetRenderer.
+func NewMarkdownRenderer() *MarkdownRenderer {
+	return &MarkdownRenderer{}
+}
+
+func (r *MarkdownRenderer) bindWriter(w *Writer) { r.w = w }
+
+func (r *MarkdownRenderer) Header(widths []int) string { return "" }
+func (r *MarkdownRenderer) Footer(widths []int) string { return "" }
+
+func (r *MarkdownRenderer) Row(cols []string, widths []int) string {
+	return "| " + strings.Join(cols, " | ") + " |"
+}
+
+func (r *MarkdownRenderer) Separator(widths []int) string {
+	cells := make([]string, len(widths))
+	for i := range widths {
+		switch r.alignOf(i) {
+		case AlignCenter:
+			cells[i] = ":---:"
+		case AlignRight:
+			cells[i] = "----:"
+		default:
+			cells[i] = "-----"
+		}
+	}
+	return "| " + strings.Join(cells, " | ") + " |"
+}
+
+func (r *MarkdownRenderer) alignOf(col int) Align {
+	if r.w != nil {
+		return r.w.alignOf(col)
+	}
+	return AlignLeft
+}
+
+// BoxRenderer draws a Unicode box-drawing table: a top border, the first row
+// as a header, a mid border, plain body rows, and a bottom border. Like the
+// default renderer it's grid-based, so SetColumnAlign and streaming
+// OverflowPolicy both apply to its cells.
+type BoxRenderer struct{}
+
+func (BoxRenderer) grid() {}
+
+func (BoxRenderer) Header(widths []int) string    { return boxBorder(widths, "┌", "┬", "┐") }
+func (BoxRenderer) Separator(widths []int) string { return boxBorder(widths, "├", "┼", "┤") }
+func (BoxRenderer) Footer(widths []int) string    { return boxBorder(widths, "└", "┴", "┘") }
+
+func (BoxRenderer) Row(cols []string, widths []int) string {
+	cells := make([]string, len(cols))
+	for i, c := range cols {
+		cells[i] = " " + c + " "
+	}
+	return "│" + strings.Join(cells, "│") + "│"
+}
+
+func boxBorder(widths []int, left, mid, right string) string {
+	cells := make([]string, len(widths))
+	for i, width := range widths {
+		cells[i] = strings.Repeat("─", width+2)
+	}
+	return left + strings.Join(cells, mid) + right
+}
+
+// CSVRenderer renders rows as CSV, delegating to encoding/csv for quoting.
+// Comma defaults to ',' when zero; set it to '\t' for TSV output, or use
+// NewTSVRenderer. It writes the buffered cell values without padding,
+// truncation or wrapping; AlignDecimal cells arrive number-formatted.
+type CSVRenderer struct {
+	Comma rune
+}
+
+// NewTSVRenderer returns a CSVRenderer configured to emit tab-separated output.
+func NewTSVRenderer() *CSVRenderer {
+	return &CSVRenderer{Comma: '\t'}
+}
+
+func (*CSVRenderer) Header(widths []int) string    { return "" }
+func (*CSVRenderer) Separator(widths []int) string { return "" }
+func (*CSVRenderer) Footer(widths []int) string    { return "" }
+
+func (r *CSVRenderer) Row(cols []string, widths []int) string {
+	var buf bytes.Buffer
+	cw := csv.NewWriter(&buf)
+	if r.Comma != 0 {
+		cw.Comma = r.Comma
+	}
+	cw.Write(cols)
+	cw.Flush()
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+// JSONRenderer renders each row as a single-line JSON object, keyed by the
+// header set via SetHeader. Columns beyond the header get a "colN" key. It
+// writes the buffered cell values without padding, truncation or wrapping;
+// AlignDecimal cells arrive number-formatted.
+type JSONRenderer struct {
+	header []string
+}
+
+// SetHeader sets the column names JSONRenderer uses as object keys.
+func (r *JSONRenderer) SetHeader(header []string) {
+	r.header = header
+}
+
+func (*JSONRenderer) Header(widths []int) string    { return "" }
+func (*JSONRenderer) Separator(widths []int) string { return "" }
+func (*JSONRenderer) Footer(widths []int) string    { return "" }
+
+func (r *JSONRenderer) Row(cols []string, widths []int) string {
+	obj := make(map[string]string, len(cols))
+	for i, c := range cols {
+		key := fmt.Sprintf("col%d", i)
+		if i < len(r.header) {
+			key = r.header[i]
+		}
+		obj[key] = c
+	}
+	b, err := json.Marshal(obj)
+	if err != nil {
+		return ""
+	}
+	return string(b)
+}
+
 type ColumnCountError struct {
 	Want, Got int
+	Line      int
 }
 
 func (ce *ColumnCountError) Error() string {
-	return fmt.Sprintf("want %d cols got %d", ce.Want, ce.Got)
+	return fmt.Sprintf("line %d, want %d cols got %d", ce.Line, ce.Want, ce.Got)
 }
 
 var ansiEscExpr = regexp.MustCompile("[\u001B\u009B][[\\]()#;?]*(?:(?:(?:[a-zA-Z\\d]*(?:;[a-zA-Z\\d]*)*)?\u0007)|(?:(?:\\d{1,4}(?:;\\d{0,4})*)?[\\dA-PRZcf-ntqry=><~]))")
@@ -201,9 +921,10 @@ func FormatLines(lines []string) {
 		return
 	}
 
+	w := &Writer{widths: widths}
 	formatted := make([]string, len(rows))
 	for i, r := range rows {
-		formatted[i] = formatRow(r, widths, "", " ", "")
+		formatted[i] = w.formatRow(r, " ")
 	}
 
 	for i := range formatted {
@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	"go.senan.xyz/table/table"
+	"golang.org/x/text/language"
 )
 
 func TestTable(t *testing.T) {
@@ -28,10 +29,9 @@ func TestTableError(t *testing.T) {
 	var buff bytes.Buffer
 	tbl := table.New(&buff)
 
-	fmt.Fprintf(&buff, "%s\t%s\t%s\n", "", "b", "c!")
-	fmt.Fprintf(&buff, "%s\t%s\n", "1", "2")
-	fmt.Fprintf(&buff, "%s\t%s\n", "3", "4")
-	tNoErr(t, tbl.Flush())
+	fmt.Fprintf(tbl, "%s\t%s\t%s\n", "", "b", "c!")
+	fmt.Fprintf(tbl, "%s\t%s\n", "1", "2")
+	fmt.Fprintf(tbl, "%s\t%s\n", "3", "4")
 
 	var re *table.ColumnCountError
 	if !errors.As(tbl.Flush(), &re) {
@@ -40,7 +40,210 @@ func TestTableError(t *testing.T) {
 	tEq(t, re.Line, 2)
 	tEq(t, re.Want, 3)
 	tEq(t, re.Got, 2)
+}
+
+func TestTableColumnAlign(t *testing.T) {
+	var buff bytes.Buffer
+	tbl := table.New(&buff)
+	tbl.SetColumnAlign(0, table.AlignRight)
+	tbl.SetColumnAlign(1, table.AlignCenter)
+	fmt.Fprintf(tbl, "%s\t%s\n", "a", "bb")
+	fmt.Fprintf(tbl, "%s\t%s\n", "aaa", "b")
+	tNoErr(t, tbl.Flush())
+	tEq(t, tRead(t, &buff), "  a bb\naaa b \n")
+}
+
+func TestTableColumnAlignDecimal(t *testing.T) {
+	var buff bytes.Buffer
+	tbl := table.New(&buff)
+	tbl.SetColumnAlign(0, table.AlignDecimal)
+	fmt.Fprintf(tbl, "%s\n", "1234.5")
+	fmt.Fprintf(tbl, "%s\n", "12.75")
+	tNoErr(t, tbl.Flush())
+	tEq(t, tRead(t, &buff), "1234.5 \n  12.75\n")
+}
+
+func TestTableColumnAlignDecimalMixed(t *testing.T) {
+	var buff bytes.Buffer
+	tbl := table.New(&buff)
+	tbl.SetColumnAlign(0, table.AlignDecimal)
+	fmt.Fprintf(tbl, "%s\t%s\n", "1000000", "x")
+	fmt.Fprintf(tbl, "%s\t%s\n", "12.75", "y")
+	tNoErr(t, tbl.Flush())
+	tEq(t, tRead(t, &buff), "1000000    x\n     12.75 y\n")
+}
+
+func TestTableLocale(t *testing.T) {
+	var buff bytes.Buffer
+	tbl := table.New(&buff)
+	tbl.SetColumnAlign(0, table.AlignDecimal)
+	tbl.SetLocale(language.German)
+	fmt.Fprintf(tbl, "%s\n", "1234567.89")
+	tNoErr(t, tbl.Flush())
+	tEq(t, tRead(t, &buff), "1.234.567,89\n")
+}
+
+func TestTableWriteTree(t *testing.T) {
+	var buff bytes.Buffer
+	tbl := table.New(&buff)
+
+	root := table.NewNode("root", "0")
+	a := root.AddChild("a", "1")
+	a.AddChild("a1", "2")
+	b := root.AddChild("b", "3")
+	b.AddChild("b1", "4")
+
+	tNoErr(t, tbl.WriteTree(root))
+	tNoErr(t, tbl.Flush())
+	tEq(t, tRead(t, &buff), "root     0\n├─ a     1\n│  └─ a1 2\n└─ b     3\n   └─ b1 4\n")
+}
+
+func TestTableWriteTreeMixedUse(t *testing.T) {
+	var buff bytes.Buffer
+	tbl := table.New(&buff)
+	fmt.Fprintf(tbl, "%s\n", "a")
+	if !errors.Is(tbl.WriteTree(table.NewNode("b")), table.ErrMixedUse) {
+		t.Fatal("expected ErrMixedUse")
+	}
+}
+
+func TestTableStreamingNeverShrinks(t *testing.T) {
+	var buff bytes.Buffer
+	tbl := table.New(&buff)
+	tbl.SetStreaming(nil, table.Grow)
+
+	fmt.Fprintf(tbl, "%s\t%s\n", "aaaaa", "b")
+	tNoErr(t, tbl.Flush())
+	tEq(t, tRead(t, &buff), "aaaaa b\n")
+
+	fmt.Fprintf(tbl, "%s\t%s\n", "a", "b")
+	tNoErr(t, tbl.Flush())
+	tEq(t, tRead(t, &buff), "a     b\n")
+}
+
+func TestTableStreamingTruncate(t *testing.T) {
+	var buff bytes.Buffer
+	tbl := table.New(&buff)
+	tbl.SetStreaming(nil, table.Truncate)
+	tbl.SetMaxWidths([]int{8})
+
+	fmt.Fprintf(tbl, "%s\n", "hello world")
+	tNoErr(t, tbl.Flush())
+	tEq(t, tRead(t, &buff), "hello w…\n")
+}
 
+func TestTableStreamingWrap(t *testing.T) {
+	var buff bytes.Buffer
+	tbl := table.New(&buff)
+	tbl.SetStreaming(nil, table.Wrap)
+	tbl.SetMaxWidths([]int{5, 0})
+	tbl.SetFormat("", "|", "")
+
+	fmt.Fprintf(tbl, "%s\t%s\n", "helloworld", "x")
+	tNoErr(t, tbl.Flush())
+	tEq(t, tRead(t, &buff), "hello | x\nworld |  \n")
+}
+
+func TestTableMarkdownRenderer(t *testing.T) {
+	var buff bytes.Buffer
+	tbl := table.New(&buff)
+	tbl.SetColumnAlign(1, table.AlignCenter)
+	tbl.SetRenderer(table.NewMarkdownRenderer())
+	fmt.Fprintf(tbl, "%s\t%s\n", "a", "bb")
+	fmt.Fprintf(tbl, "%s\t%s\n", "aaa", "b")
+	tNoErr(t, tbl.Flush())
+	tEq(t, tRead(t, &buff), "| a | bb |\n| ----- | :---: |\n| aaa | b |\n")
+}
+
+func TestTableBoxRenderer(t *testing.T) {
+	var buff bytes.Buffer
+	tbl := table.New(&buff)
+	tbl.SetRenderer(table.BoxRenderer{})
+	fmt.Fprintf(tbl, "%s\t%s\n", "a", "bb")
+	fmt.Fprintf(tbl, "%s\t%s\n", "aaa", "b")
+	tNoErr(t, tbl.Flush())
+	tEq(t, tRead(t, &buff), "┌─────┬────┐\n│ a   │ bb │\n├─────┼────┤\n│ aaa │ b  │\n└─────┴────┘\n")
+}
+
+func TestTableBoxRendererAlign(t *testing.T) {
+	var buff bytes.Buffer
+	tbl := table.New(&buff)
+	tbl.SetColumnAlign(0, table.AlignRight)
+	tbl.SetRenderer(table.BoxRenderer{})
+	fmt.Fprintf(tbl, "%s\t%s\n", "a", "bb")
+	fmt.Fprintf(tbl, "%s\t%s\n", "aaa", "b")
+	tNoErr(t, tbl.Flush())
+	tEq(t, tRead(t, &buff), "┌─────┬────┐\n│   a │ bb │\n├─────┼────┤\n│ aaa │ b  │\n└─────┴────┘\n")
+}
+
+func TestTableBoxRendererStreamingTruncate(t *testing.T) {
+	var buff bytes.Buffer
+	tbl := table.New(&buff)
+	tbl.SetStreaming(nil, table.Truncate)
+	tbl.SetMaxWidths([]int{5})
+	tbl.SetRenderer(table.BoxRenderer{})
+	fmt.Fprintf(tbl, "%s\n", "hello world")
+	tNoErr(t, tbl.Flush())
+	tEq(t, tRead(t, &buff), "┌───────┐\n│ hell… │\n├───────┤\n└───────┘\n")
+}
+
+func TestTableBoxRendererStreamingWrap(t *testing.T) {
+	var buff bytes.Buffer
+	tbl := table.New(&buff)
+	tbl.SetStreaming(nil, table.Wrap)
+	tbl.SetMaxWidths([]int{5, 0})
+	tbl.SetRenderer(table.BoxRenderer{})
+	fmt.Fprintf(tbl, "%s\t%s\n", "helloworld", "x")
+	tNoErr(t, tbl.Flush())
+	tEq(t, tRead(t, &buff), "┌───────┬───┐\n│ hello │ x │\n│ world │   │\n├───────┼───┤\n└───────┴───┘\n")
+}
+
+func TestTableCSVRendererIgnoresAlignAndOverflow(t *testing.T) {
+	var buff bytes.Buffer
+	tbl := table.New(&buff)
+	tbl.SetColumnAlign(0, table.AlignRight)
+	tbl.SetStreaming(nil, table.Truncate)
+	tbl.SetMaxWidths([]int{5})
+	tbl.SetRenderer(&table.CSVRenderer{})
+	fmt.Fprintf(tbl, "%s\n", "hello world")
+	tNoErr(t, tbl.Flush())
+	tEq(t, tRead(t, &buff), "hello world\n")
+}
+
+func TestTableJSONRendererIgnoresAlignAndOverflow(t *testing.T) {
+	var buff bytes.Buffer
+	tbl := table.New(&buff)
+	tbl.SetColumnAlign(0, table.AlignRight)
+	tbl.SetStreaming(nil, table.Truncate)
+	tbl.SetMaxWidths([]int{5})
+	r := &table.JSONRenderer{}
+	r.SetHeader([]string{"name"})
+	tbl.SetRenderer(r)
+	fmt.Fprintf(tbl, "%s\n", "hello world")
+	tNoErr(t, tbl.Flush())
+	tEq(t, tRead(t, &buff), "{\"name\":\"hello world\"}\n")
+}
+
+func TestTableCSVRenderer(t *testing.T) {
+	var buff bytes.Buffer
+	tbl := table.New(&buff)
+	tbl.SetRenderer(&table.CSVRenderer{})
+	fmt.Fprintf(tbl, "%s\t%s\n", "a", "b,c")
+	fmt.Fprintf(tbl, "%s\t%s\n", "d", "e")
+	tNoErr(t, tbl.Flush())
+	tEq(t, tRead(t, &buff), "a,\"b,c\"\nd,e\n")
+}
+
+func TestTableJSONRenderer(t *testing.T) {
+	var buff bytes.Buffer
+	tbl := table.New(&buff)
+	r := &table.JSONRenderer{}
+	r.SetHeader([]string{"name", "age"})
+	tbl.SetRenderer(r)
+	fmt.Fprintf(tbl, "%s\t%s\n", "alice", "30")
+	fmt.Fprintf(tbl, "%s\t%s\n", "bob", "40")
+	tNoErr(t, tbl.Flush())
+	tEq(t, tRead(t, &buff), "{\"age\":\"30\",\"name\":\"alice\"}\n{\"age\":\"40\",\"name\":\"bob\"}\n")
 }
 
 func tNoErr(t *testing.T, err error) {